@@ -2,68 +2,15 @@
 // This script demonstrates the mathematical beauty of Euclidean rhythms
 // by generating and displaying various famous patterns from world music.
 //
-// Run with: go run demo.go
+// Run with: go run ./cmd/euclid-demo
 
 package main
 
 import (
 	"fmt"
-	"strings"
-)
-
-// bjorklund generates a Euclidean rhythm pattern (copied from main.go for standalone demo)
-func bjorklund(steps, pulses int) []int {
-	if pulses == 0 {
-		return make([]int, steps)
-	}
-	if pulses == steps {
-		out := make([]int, steps)
-		for i := range out {
-			out[i] = 1
-		}
-		return out
-	}
-	groups := make([][]int, steps)
-	for i := 0; i < steps; i++ {
-		if i < pulses {
-			groups[i] = []int{1}
-		} else {
-			groups[i] = []int{0}
-		}
-	}
-	for {
-		count := 0
-		for i := 0; i < len(groups)-1; i++ {
-			if len(groups[i]) == 1 && len(groups[len(groups)-1]) == 1 && 
-			   groups[i][0] != groups[len(groups)-1][0] {
-				groups[i] = append(groups[i], groups[len(groups)-1][0])
-				groups = groups[:len(groups)-1]
-				count++
-			}
-		}
-		if count == 0 {
-			break
-		}
-	}
-	pattern := make([]int, 0, steps)
-	for _, g := range groups {
-		pattern = append(pattern, g...)
-	}
-	return pattern
-}
 
-// formatPattern converts a pattern to visual representation
-func formatPattern(pattern []int) string {
-	var result strings.Builder
-	for _, v := range pattern {
-		if v == 1 {
-			result.WriteString("X")
-		} else {
-			result.WriteString(".")
-		}
-	}
-	return result.String()
-}
+	"github.com/luisgizirian/lab-audio/pkg/euclid"
+)
 
 // rhythmExample represents a famous rhythm pattern
 type rhythmExample struct {
@@ -141,14 +88,13 @@ func main() {
 	}
 
 	for i, example := range examples {
-		pattern := bjorklund(example.steps, example.pulses)
-		visual := formatPattern(pattern)
-		
+		pattern := euclid.Bjorklund(example.steps, example.pulses)
+
 		fmt.Printf("%d. %s (%d/%d)\n", i+1, example.name, example.pulses, example.steps)
-		fmt.Printf("   Pattern: %s\n", visual)
+		fmt.Printf("   Pattern: %s\n", pattern)
 		fmt.Printf("   Origin:  %s\n", example.origin)
 		fmt.Printf("   Notes:   %s\n", example.description)
-		fmt.Printf("   Density: %.1f%% (%.1f pulses per beat)\n", 
+		fmt.Printf("   Density: %.1f%% (%.1f pulses per beat)\n",
 			float64(example.pulses)/float64(example.steps)*100,
 			float64(example.pulses*4)/float64(example.steps))
 		fmt.Println()
@@ -162,7 +108,7 @@ func main() {
 	fmt.Println("• These patterns naturally emerge in traditional music worldwide")
 	fmt.Println("• Musicians often discover them intuitively without knowing the mathematics")
 	fmt.Println("")
-	
+
 	fmt.Println("🎛️  EXPERIMENT IDEAS")
 	fmt.Println("====================")
 	fmt.Println("Try modifying the main program with different values:")
@@ -171,9 +117,9 @@ func main() {
 	fmt.Println("• Modify drum frequency for different pitches")
 	fmt.Println("• Layer multiple patterns for polyrhythms")
 	fmt.Println("")
-	
+
 	fmt.Println("To generate any of these patterns as audio:")
-	fmt.Println("1. Edit the 'steps' and 'pulses' values in main.go")
-	fmt.Println("2. Run: go run main.go")
+	fmt.Println("1. Edit the 'steps' and 'pulses' values in cmd/euclidgen/main.go")
+	fmt.Println("2. Run: go run ./cmd/euclidgen")
 	fmt.Println("3. Listen to the generated euclid.wav file")
-}
\ No newline at end of file
+}
@@ -8,88 +8,51 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
 	"github.com/go-audio/audio"
 	"github.com/go-audio/wav"
-	"math"
+	"github.com/luisgizirian/lab-audio/pkg/euclid"
+	"github.com/luisgizirian/lab-audio/pkg/synth"
 )
 
-// Copy the necessary functions from main.go
-func bjorklund(steps, pulses int) []int {
-	if pulses == 0 {
-		return make([]int, steps)
-	}
-	if pulses == steps {
-		out := make([]int, steps)
-		for i := range out {
-			out[i] = 1
-		}
-		return out
-	}
-	groups := make([][]int, steps)
-	for i := 0; i < steps; i++ {
-		if i < pulses {
-			groups[i] = []int{1}
-		} else {
-			groups[i] = []int{0}
-		}
-	}
-	for {
-		count := 0
-		for i := 0; i < len(groups)-1; i++ {
-			if len(groups[i]) == 1 && len(groups[len(groups)-1]) == 1 && 
-			   groups[i][0] != groups[len(groups)-1][0] {
-				groups[i] = append(groups[i], groups[len(groups)-1][0])
-				groups = groups[:len(groups)-1]
-				count++
-			}
-		}
-		if count == 0 {
-			break
-		}
-	}
-	pattern := make([]int, 0, steps)
-	for _, g := range groups {
-		pattern = append(pattern, g...)
-	}
-	return pattern
-}
-
-func synthDrum(sampleRate, lengthMs int, freq float64) []int {
-	samples := sampleRate * lengthMs / 1000
-	buf := make([]int, samples)
-	
-	for i := 0; i < samples; i++ {
-		amp := 0.5 * math.Exp(-4*float64(i)/float64(samples))
-		phase := 2 * math.Pi * freq * float64(i) / float64(sampleRate)
-		buf[i] = int(amp * 32767 * math.Sin(phase))
-	}
-	return buf
-}
-
 // RhythmConfig holds parameters for generating a rhythm
 type RhythmConfig struct {
 	Name       string
 	Steps      int
 	Pulses     int
 	BPM        int
-	DrumFreq   float64
+	Voice      string // drum preset: "kick", "snare", "hat", or "clap"
 	OutputFile string
 }
 
+// newVoice builds the SynthVoice preset named by voice, falling back to
+// NewKick for an unrecognized name.
+func newVoice(voice string, sampleRate int) *synth.SynthVoice {
+	switch voice {
+	case "snare":
+		return synth.NewSnare(sampleRate)
+	case "hat":
+		return synth.NewHat(sampleRate)
+	case "clap":
+		return synth.NewClap(sampleRate)
+	default:
+		return synth.NewKick(sampleRate)
+	}
+}
+
 // generateRhythm creates a WAV file for the given rhythm configuration
 func generateRhythm(config RhythmConfig) error {
 	sampleRate := 44100
-	drumLengthMs := 80
 	beatMs := 60000 / config.BPM
-	
+
 	// Generate pattern and drum sound
-	pattern := bjorklund(config.Steps, config.Pulses)
-	drum := synthDrum(sampleRate, drumLengthMs, config.DrumFreq)
-	
+	pattern := euclid.Bjorklund(config.Steps, config.Pulses)
+	drum := newVoice(config.Voice, sampleRate).Samples()
+
 	// Create audio buffer
 	totalSamples := sampleRate * config.Steps * beatMs / 1000
 	out := make([]int, totalSamples)
-	
+
 	// Place drum hits
 	for i, v := range pattern {
 		if v == 1 {
@@ -99,21 +62,21 @@ func generateRhythm(config RhythmConfig) error {
 			}
 		}
 	}
-	
+
 	// Create output file
 	f, err := os.Create(config.OutputFile)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	
+
 	// Encode to WAV
 	buf := &audio.IntBuffer{
 		Data:           out,
 		Format:         &audio.Format{SampleRate: sampleRate, NumChannels: 1},
 		SourceBitDepth: 16,
 	}
-	
+
 	enc := wav.NewEncoder(f, sampleRate, 16, 1, 1)
 	if err := enc.Write(buf); err != nil {
 		return err
@@ -121,18 +84,10 @@ func generateRhythm(config RhythmConfig) error {
 	if err := enc.Close(); err != nil {
 		return err
 	}
-	
+
 	// Display pattern
-	fmt.Printf("Generated %s: ", config.Name)
-	for _, v := range pattern {
-		if v == 1 {
-			fmt.Print("X")
-		} else {
-			fmt.Print(".")
-		}
-	}
-	fmt.Printf(" -> %s\n", config.OutputFile)
-	
+	fmt.Printf("Generated %s: %s -> %s\n", config.Name, pattern, config.OutputFile)
+
 	return nil
 }
 
@@ -141,14 +96,14 @@ func main() {
 	fmt.Println("========================================")
 	fmt.Println("Generating a collection of famous Euclidean rhythms...")
 	fmt.Println()
-	
+
 	// Create examples directory
 	outputDir := "examples"
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		fmt.Printf("Error creating output directory: %v\n", err)
 		return
 	}
-	
+
 	// Collection of famous rhythms
 	rhythms := []RhythmConfig{
 		{
@@ -156,7 +111,7 @@ func main() {
 			Steps:      8,
 			Pulses:     3,
 			BPM:        120,
-			DrumFreq:   180.0,
+			Voice:      "kick",
 			OutputFile: filepath.Join(outputDir, "cuban_tresillo.wav"),
 		},
 		{
@@ -164,7 +119,7 @@ func main() {
 			Steps:      8,
 			Pulses:     5,
 			BPM:        100,
-			DrumFreq:   200.0,
+			Voice:      "snare",
 			OutputFile: filepath.Join(outputDir, "turkish_aksak.wav"),
 		},
 		{
@@ -172,7 +127,7 @@ func main() {
 			Steps:      12,
 			Pulses:     5,
 			BPM:        110,
-			DrumFreq:   160.0,
+			Voice:      "hat",
 			OutputFile: filepath.Join(outputDir, "west_african.wav"),
 		},
 		{
@@ -180,7 +135,7 @@ func main() {
 			Steps:      16,
 			Pulses:     6,
 			BPM:        120,
-			DrumFreq:   180.0,
+			Voice:      "clap",
 			OutputFile: filepath.Join(outputDir, "bossa_nova.wav"),
 		},
 		{
@@ -188,19 +143,19 @@ func main() {
 			Steps:      5,
 			Pulses:     2,
 			BPM:        90,
-			DrumFreq:   220.0,
+			Voice:      "kick",
 			OutputFile: filepath.Join(outputDir, "minimalist.wav"),
 		},
 	}
-	
+
 	// Generate all rhythms
 	for _, rhythm := range rhythms {
 		if err := generateRhythm(rhythm); err != nil {
 			fmt.Printf("Error generating %s: %v\n", rhythm.Name, err)
 		}
 	}
-	
+
 	fmt.Printf("\n✓ Generated %d rhythm examples in '%s/' directory\n", len(rhythms), outputDir)
 	fmt.Println("\nListen to the different patterns and compare their feels!")
-	fmt.Println("Each pattern uses slightly different tempo and pitch for character.")
-}
\ No newline at end of file
+	fmt.Println("Each pattern uses a different tempo and drum voice for character.")
+}
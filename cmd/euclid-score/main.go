@@ -0,0 +1,70 @@
+// Command euclid-score renders a text score (see pkg/score) to a WAV file,
+// and optionally a Standard MIDI File alongside it.
+//
+// Usage: euclid-score <score-file> [output.wav] [output.mid]
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/luisgizirian/lab-audio/pkg/midi"
+	"github.com/luisgizirian/lab-audio/pkg/score"
+)
+
+// midiPPQ is the ticks-per-quarter-note resolution used for MIDI export.
+const midiPPQ = 480
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("usage: euclid-score <score-file> [output.wav] [output.mid]")
+		os.Exit(1)
+	}
+	scorePath := os.Args[1]
+	outputFile := "score.wav"
+	if len(os.Args) > 2 {
+		outputFile = os.Args[2]
+	}
+	var midiFile string
+	if len(os.Args) > 3 {
+		midiFile = os.Args[3]
+	}
+
+	sequencer, err := score.Load(scorePath)
+	if err != nil {
+		fmt.Printf("Error loading score %s: %v\n", scorePath, err)
+		os.Exit(1)
+	}
+
+	f, err := os.Create(outputFile)
+	if err != nil {
+		fmt.Printf("Error creating file %s: %v\n", outputFile, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	if err := sequencer.RenderTo(f); err != nil {
+		fmt.Printf("Error rendering score: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Rendered '%s' from score '%s'\n", outputFile, scorePath)
+
+	if midiFile == "" {
+		return
+	}
+
+	mf, err := os.Create(midiFile)
+	if err != nil {
+		fmt.Printf("Error creating file %s: %v\n", midiFile, err)
+		os.Exit(1)
+	}
+	defer mf.Close()
+
+	if err := midi.WriteSequencer(mf, sequencer, midiPPQ); err != nil {
+		fmt.Printf("Error writing MIDI %s: %v\n", midiFile, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Wrote '%s' from score '%s'\n", midiFile, scorePath)
+}
@@ -0,0 +1,216 @@
+// Package euclid generates Euclidean rhythm patterns using the Bjorklund
+// algorithm and provides a small pattern algebra (rotation, complement,
+// boolean combination, and nested distributions) for composing them.
+//
+// Euclidean rhythms distribute a given number of pulses as evenly as possible
+// across a specified number of time steps, creating naturally pleasing
+// rhythmic patterns found in many musical traditions worldwide.
+package euclid
+
+// Pattern is a rhythmic pattern where 1 represents a hit and 0 represents a
+// rest. Its length is the number of steps in the cycle.
+type Pattern []int
+
+// Bjorklund generates a Euclidean rhythm pattern using the Bjorklund
+// algorithm.
+//
+// The algorithm distributes 'pulses' as evenly as possible across 'steps'
+// time intervals. It returns a Pattern where 1 represents a hit and 0
+// represents silence.
+//
+// Examples:
+//   - Bjorklund(8, 3) returns [1,0,0,1,0,0,1,0] (Cuban tresillo)
+//   - Bjorklund(16, 6) returns [1,0,0,1,0,1,0,0,1,0,0,1,0,1,0,0]
+//
+// Parameters:
+//   - steps: total number of time intervals (must be positive)
+//   - pulses: number of hits to distribute (must be <= steps)
+//
+// Returns:
+//   - Pattern: pattern where 1=hit, 0=rest
+func Bjorklund(steps, pulses int) Pattern {
+	// Handle edge cases
+	if pulses == 0 {
+		return make(Pattern, steps) // all zeros
+	}
+	if pulses == steps {
+		out := make(Pattern, steps)
+		for i := range out {
+			out[i] = 1 // all ones
+		}
+		return out
+	}
+
+	// ones holds the 'pulses' groups that start as a single hit, zeros the
+	// remaining groups that start as a single rest.
+	ones := make([][]int, pulses)
+	for i := range ones {
+		ones[i] = []int{1}
+	}
+	zeros := make([][]int, steps-pulses)
+	for i := range zeros {
+		zeros[i] = []int{0}
+	}
+
+	// Repeatedly append one remainder group onto the front of each
+	// leading group, the classic Bjorklund pairing step. The pairing
+	// boundary (n) is snapshotted before either slice is reassigned, so
+	// the loop never reads a length it is simultaneously shrinking.
+	for len(zeros) > 1 {
+		n := len(ones)
+		if len(zeros) < n {
+			n = len(zeros)
+		}
+		paired := make([][]int, n)
+		for i := 0; i < n; i++ {
+			paired[i] = append(append([]int{}, ones[i]...), zeros[i]...)
+		}
+		var remainder [][]int
+		if len(ones) > n {
+			remainder = ones[n:]
+		} else {
+			remainder = zeros[n:]
+		}
+		ones = paired
+		zeros = remainder
+	}
+
+	// Flatten the leading groups followed by whatever remainder is left.
+	pattern := make(Pattern, 0, steps)
+	for _, g := range ones {
+		pattern = append(pattern, g...)
+	}
+	for _, g := range zeros {
+		pattern = append(pattern, g...)
+	}
+	return pattern
+}
+
+// String renders the pattern as a line of 'X' (hit) and '.' (rest)
+// characters, the visualization used throughout this project's CLIs.
+func (p Pattern) String() string {
+	out := make([]byte, len(p))
+	for i, v := range p {
+		if v == 1 {
+			out[i] = 'X'
+		} else {
+			out[i] = '.'
+		}
+	}
+	return string(out)
+}
+
+// Rotate returns a copy of the pattern cyclically shifted by n steps
+// (Toussaint's "necklace rotation"). A positive n moves the first step of
+// the pattern later in the cycle; negative n rotates the other way. The
+// rotation amount is taken modulo the pattern length, so any integer n is
+// accepted.
+func (p Pattern) Rotate(n int) Pattern {
+	if len(p) == 0 {
+		return Pattern{}
+	}
+	n = ((n % len(p)) + len(p)) % len(p)
+	out := make(Pattern, len(p))
+	for i := range p {
+		out[(i+n)%len(p)] = p[i]
+	}
+	return out
+}
+
+// Complement returns the inverse pattern: every hit becomes a rest and
+// every rest becomes a hit.
+func (p Pattern) Complement() Pattern {
+	out := make(Pattern, len(p))
+	for i, v := range p {
+		if v == 0 {
+			out[i] = 1
+		}
+	}
+	return out
+}
+
+// And returns the step-wise logical AND of two equal-length patterns: a
+// step is a hit only where both patterns have a hit. It panics if the
+// patterns have different lengths.
+func (p Pattern) And(other Pattern) Pattern {
+	p.mustMatch(other)
+	out := make(Pattern, len(p))
+	for i := range p {
+		if p[i] != 0 && other[i] != 0 {
+			out[i] = 1
+		}
+	}
+	return out
+}
+
+// Or returns the step-wise logical OR of two equal-length patterns: a step
+// is a hit where either pattern has a hit. It panics if the patterns have
+// different lengths.
+func (p Pattern) Or(other Pattern) Pattern {
+	p.mustMatch(other)
+	out := make(Pattern, len(p))
+	for i := range p {
+		if p[i] != 0 || other[i] != 0 {
+			out[i] = 1
+		}
+	}
+	return out
+}
+
+// Xor returns the step-wise logical XOR of two equal-length patterns: a
+// step is a hit where exactly one of the patterns has a hit. It panics if
+// the patterns have different lengths.
+func (p Pattern) Xor(other Pattern) Pattern {
+	p.mustMatch(other)
+	out := make(Pattern, len(p))
+	for i := range p {
+		if (p[i] != 0) != (other[i] != 0) {
+			out[i] = 1
+		}
+	}
+	return out
+}
+
+// mustMatch panics with a descriptive message if p and other have
+// different lengths, the precondition shared by And, Or, and Xor.
+func (p Pattern) mustMatch(other Pattern) {
+	if len(p) != len(other) {
+		panic("euclid: patterns must have equal length")
+	}
+}
+
+// NestedEuclid generates a "Euclid of a Euclid": it first lays out m hits
+// across n steps with Bjorklund(n, m), then keeps only k of those m hits,
+// chosen by distributing a second Euclidean rhythm, Bjorklund(m, k), over
+// the hit positions of the first. This is the idiom composers use to
+// thin out a Euclidean line into an interlocking part without losing the
+// evenness of the original distribution.
+//
+// For example, NestedEuclid(3, 5, 8) starts from the 5-over-8 pattern and
+// keeps 3 of its 5 hits, evenly spaced among them.
+//
+// Parameters:
+//   - k: number of hits to keep, must be <= m
+//   - m: number of hits in the outer pattern, must be <= n
+//   - n: total number of steps in the outer pattern
+//
+// Returns:
+//   - Pattern: an n-step pattern with k hits selected from the m hits of
+//     Bjorklund(n, m)
+func NestedEuclid(k, m, n int) Pattern {
+	outer := Bjorklund(n, m)
+	selector := Bjorklund(m, k)
+
+	hitIndex := 0
+	out := make(Pattern, n)
+	for i, v := range outer {
+		if v == 0 {
+			continue
+		}
+		if selector[hitIndex] == 1 {
+			out[i] = 1
+		}
+		hitIndex++
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package euclid
+
+import "testing"
+
+func patternString(steps, pulses int) string {
+	return Bjorklund(steps, pulses).String()
+}
+
+func TestBjorklund(t *testing.T) {
+	cases := []struct {
+		steps, pulses int
+		want          string
+	}{
+		{8, 3, "X..X..X."},
+		{8, 5, "X.XX.XX."},
+		{5, 3, "X.X.X"},
+		{4, 0, "...."},
+		{4, 4, "XXXX"},
+	}
+	for _, c := range cases {
+		if got := patternString(c.steps, c.pulses); got != c.want {
+			t.Errorf("Bjorklund(%d, %d) = %q, want %q", c.steps, c.pulses, got, c.want)
+		}
+	}
+}
+
+func TestNestedEuclid(t *testing.T) {
+	got := NestedEuclid(3, 5, 8).String()
+	want := "X..X..X."
+	if got != want {
+		t.Errorf("NestedEuclid(3, 5, 8) = %q, want %q", got, want)
+	}
+}
+
+func TestPatternRotate(t *testing.T) {
+	p := Bjorklund(8, 3)
+	if got := p.Rotate(0).String(); got != p.String() {
+		t.Errorf("Rotate(0) = %q, want %q", got, p.String())
+	}
+	if got := p.Rotate(len(p)).String(); got != p.String() {
+		t.Errorf("Rotate(len(p)) = %q, want %q", got, p.String())
+	}
+	// Rotating by -1 and then 1 should return to the original pattern.
+	if got := p.Rotate(-1).Rotate(1).String(); got != p.String() {
+		t.Errorf("Rotate(-1).Rotate(1) = %q, want %q", got, p.String())
+	}
+}
+
+func TestPatternComplement(t *testing.T) {
+	p := Pattern{1, 0, 1, 0}
+	want := "X.X."
+	if p.String() != want {
+		t.Fatalf("setup: p.String() = %q, want %q", p.String(), want)
+	}
+	got := p.Complement().String()
+	if want := ".X.X"; got != want {
+		t.Errorf("Complement() = %q, want %q", got, want)
+	}
+}
+
+func TestPatternAndOrXor(t *testing.T) {
+	a := Pattern{1, 1, 0, 0}
+	b := Pattern{1, 0, 1, 0}
+
+	if got, want := a.And(b).String(), "X..."; got != want {
+		t.Errorf("And() = %q, want %q", got, want)
+	}
+	if got, want := a.Or(b).String(), "XXX."; got != want {
+		t.Errorf("Or() = %q, want %q", got, want)
+	}
+	if got, want := a.Xor(b).String(), ".XX."; got != want {
+		t.Errorf("Xor() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,269 @@
+// Package midi writes generated Euclidean patterns out as Standard MIDI
+// Files, so a groove can be taken into a DAW for further arrangement
+// instead of only being heard as a rendered WAV.
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"github.com/luisgizirian/lab-audio/pkg/euclid"
+	"github.com/luisgizirian/lab-audio/pkg/seq"
+)
+
+// General MIDI drum-kit note numbers for the percussion voices this
+// project generates.
+const (
+	NoteKick      = 36
+	NoteSnare     = 38
+	NoteClosedHat = 42
+	NoteOpenHat   = 46
+	NoteClap      = 39
+)
+
+// DrumChannel is the General MIDI channel (0-indexed) reserved for
+// percussion.
+const DrumChannel = 9
+
+// event is one MIDI event at an absolute tick, to be delta-encoded when
+// the track is serialized.
+type event struct {
+	tick int
+	data []byte
+}
+
+// Track accumulates the events of a single MIDI track in absolute ticks.
+type Track struct {
+	events []event
+}
+
+// NewTrack returns an empty Track.
+func NewTrack() *Track {
+	return &Track{}
+}
+
+// NoteOn appends a note-on event at tick.
+func (t *Track) NoteOn(tick, channel, note, velocity int) {
+	t.events = append(t.events, event{tick, []byte{0x90 | byte(channel), byte(note), byte(velocity)}})
+}
+
+// NoteOff appends a note-off event at tick.
+func (t *Track) NoteOff(tick, channel, note, velocity int) {
+	t.events = append(t.events, event{tick, []byte{0x80 | byte(channel), byte(note), byte(velocity)}})
+}
+
+// Meta appends a meta event (FF <type> <len> <data>) at tick.
+func (t *Track) Meta(tick int, metaType byte, data []byte) {
+	payload := append([]byte{0xFF, metaType}, appendVarLen(nil, len(data))...)
+	payload = append(payload, data...)
+	t.events = append(t.events, event{tick, payload})
+}
+
+// EndOfTrack appends the end-of-track meta event at tick. Encode adds one
+// automatically for any track that is missing it.
+func (t *Track) EndOfTrack(tick int) {
+	t.Meta(tick, 0x2F, nil)
+}
+
+// PatternToMIDITrack renders a Euclidean pattern as note-on/note-off pairs
+// on note and channel, one step every stepTicks ticks. Each hit is held
+// for half a step, short enough not to run into the next hit.
+func PatternToMIDITrack(pattern []int, note, channel, stepTicks int) *Track {
+	t := NewTrack()
+	tick := 0
+	for _, hit := range pattern {
+		if hit != 0 {
+			t.NoteOn(tick, channel, note, 100)
+			t.NoteOff(tick+stepTicks/2, channel, note, 0)
+		}
+		tick += stepTicks
+	}
+	t.EndOfTrack(tick)
+	return t
+}
+
+// TempoTrack builds the conductor track carrying the tempo (derived from
+// bpm) and a 4/4 time signature, the convention a Type 1 file uses for
+// meta events shared by every other track.
+func TempoTrack(bpm int) *Track {
+	t := NewTrack()
+	microsPerQuarter := 60000000 / bpm
+	t.Meta(0, 0x51, []byte{
+		byte(microsPerQuarter >> 16),
+		byte(microsPerQuarter >> 8),
+		byte(microsPerQuarter),
+	})
+	t.Meta(0, 0x58, []byte{4, 2, 24, 8}) // 4/4, metronome every 24 clocks, 8 32nds per quarter
+	t.EndOfTrack(0)
+	return t
+}
+
+// NoteForName maps a seq.Track's Name to a General MIDI drum note,
+// recognizing common abbreviations case-insensitively. Unrecognized names
+// fall back to NoteKick, since most lines in a Euclidean score are some
+// kind of low, pulse-carrying voice.
+func NoteForName(name string) int {
+	switch strings.ToLower(name) {
+	case "kick", "bass", "bd":
+		return NoteKick
+	case "snare", "sd":
+		return NoteSnare
+	case "hat", "hihat", "closedhat", "ch":
+		return NoteClosedHat
+	case "openhat", "oh":
+		return NoteOpenHat
+	case "clap", "cp":
+		return NoteClap
+	default:
+		return NoteKick
+	}
+}
+
+// WriteSequencer renders s as a Standard MIDI File, one track per
+// seq.Track, at the given PPQ resolution. Every track is written as
+// General MIDI percussion on DrumChannel, with the note chosen by
+// NoteForName from the track's Name. One Euclidean step is one quarter
+// note, matching the convention the rest of the package uses to turn
+// step counts into sample counts. Per-track BPM overrides are not
+// representable here, since a MIDI file carries a single tempo map
+// shared by every track; the export uses s.BPM for all of them.
+//
+// Tracks with different step counts are tiled out to a shared cycle
+// length equal to the LCM of every track's Steps, repeated s.Loops times,
+// the same way seq.Sequencer lines tracks back up to a common downbeat
+// for WAV rendering; otherwise an 8-step kick and a 16-step hat would end
+// up as MIDI tracks of different absolute lengths that never resolve to
+// the same bar.
+func WriteSequencer(w io.Writer, s *seq.Sequencer, ppq int) error {
+	tracks := make([]*Track, 0, len(s.Tracks)+1)
+	tracks = append(tracks, TempoTrack(s.BPM))
+
+	cycleSteps := 1
+	for _, t := range s.Tracks {
+		cycleSteps = lcm(cycleSteps, t.Steps)
+	}
+	loops := s.Loops
+	if loops <= 0 {
+		loops = 1
+	}
+
+	for _, t := range s.Tracks {
+		pattern := euclid.Bjorklund(t.Steps, t.Pulses).Rotate(t.Rotation)
+		cycle := tilePattern(pattern, cycleSteps)
+		full := make([]int, 0, cycleSteps*loops)
+		for i := 0; i < loops; i++ {
+			full = append(full, cycle...)
+		}
+		tracks = append(tracks, PatternToMIDITrack(full, NoteForName(t.Name), DrumChannel, ppq))
+	}
+	return Encode(w, tracks, ppq)
+}
+
+// tilePattern repeats pattern until it is exactly n steps long. n is
+// always a multiple of len(pattern) when called from WriteSequencer,
+// since n is built as an LCM of every track's step count.
+func tilePattern(pattern []int, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = pattern[i%len(pattern)]
+	}
+	return out
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// lcm returns the least common multiple of a and b.
+func lcm(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return a / gcd(a, b) * b
+}
+
+// Encode writes tracks out as a Standard MIDI File, Type 1 (one tempo
+// track plus independent, simultaneous note tracks), at the given ticks
+// per quarter note (PPQ) resolution.
+func Encode(w io.Writer, tracks []*Track, ppq int) error {
+	header := make([]byte, 6)
+	binary.BigEndian.PutUint16(header[0:2], 1) // format 1
+	binary.BigEndian.PutUint16(header[2:4], uint16(len(tracks)))
+	binary.BigEndian.PutUint16(header[4:6], uint16(ppq))
+	if err := writeChunk(w, "MThd", header); err != nil {
+		return err
+	}
+
+	for _, t := range tracks {
+		if err := writeChunk(w, "MTrk", t.serialize()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serialize converts the track's absolute-tick events into the
+// delta-time-prefixed byte stream a MIDI file stores on disk.
+func (t *Track) serialize() []byte {
+	events := make([]event, len(t.events))
+	copy(events, t.events)
+	stableSortByTick(events)
+
+	var buf bytes.Buffer
+	prevTick := 0
+	for _, e := range events {
+		buf.Write(appendVarLen(nil, e.tick-prevTick))
+		buf.Write(e.data)
+		prevTick = e.tick
+	}
+	return buf.Bytes()
+}
+
+// stableSortByTick sorts events by tick, preserving the relative order of
+// events at the same tick (e.g. a note-off and the next note-on).
+func stableSortByTick(events []event) {
+	for i := 1; i < len(events); i++ {
+		for j := i; j > 0 && events[j].tick < events[j-1].tick; j-- {
+			events[j], events[j-1] = events[j-1], events[j]
+		}
+	}
+}
+
+// writeChunk writes a four-character chunk ID, its big-endian length, and
+// its data.
+func writeChunk(w io.Writer, id string, data []byte) error {
+	if _, err := io.WriteString(w, id); err != nil {
+		return err
+	}
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(data)))
+	if _, err := w.Write(length); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// appendVarLen appends n encoded as a MIDI variable-length quantity.
+func appendVarLen(buf []byte, n int) []byte {
+	var stack [5]byte
+	size := 0
+	stack[size] = byte(n & 0x7F)
+	size++
+	n >>= 7
+	for n > 0 {
+		stack[size] = byte(n&0x7F) | 0x80
+		size++
+		n >>= 7
+	}
+	for i := size - 1; i >= 0; i-- {
+		buf = append(buf, stack[i])
+	}
+	return buf
+}
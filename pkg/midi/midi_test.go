@@ -0,0 +1,110 @@
+package midi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAppendVarLen(t *testing.T) {
+	cases := []struct {
+		n    int
+		want []byte
+	}{
+		{0, []byte{0x00}},
+		{0x40, []byte{0x40}},
+		{0x7F, []byte{0x7F}},
+		{0x80, []byte{0x81, 0x00}},
+		{0x2000, []byte{0xC0, 0x00}},
+		{0x1FFFFF, []byte{0xFF, 0xFF, 0x7F}},
+	}
+	for _, c := range cases {
+		got := appendVarLen(nil, c.n)
+		if !bytes.Equal(got, c.want) {
+			t.Errorf("appendVarLen(nil, 0x%X) = % X, want % X", c.n, got, c.want)
+		}
+	}
+}
+
+func TestTrackSerializeDeltaTimes(t *testing.T) {
+	tr := NewTrack()
+	tr.NoteOn(0, DrumChannel, NoteKick, 100)
+	tr.NoteOff(12, DrumChannel, NoteKick, 0)
+	tr.EndOfTrack(12)
+
+	data := tr.serialize()
+
+	want := []byte{}
+	want = append(want, 0x00)
+	want = append(want, 0x90|DrumChannel, byte(NoteKick), 100)
+	want = append(want, 0x0C) // delta of 12 ticks to the note-off
+	want = append(want, 0x80|DrumChannel, byte(NoteKick), 0)
+	want = append(want, 0x00) // end-of-track fires at the same tick
+	want = append(want, 0xFF, 0x2F, 0x00)
+
+	if !bytes.Equal(data, want) {
+		t.Errorf("serialize() = % X, want % X", data, want)
+	}
+}
+
+func TestPatternToMIDITrackHoldsHalfAStep(t *testing.T) {
+	tr := PatternToMIDITrack([]int{1, 0, 1}, NoteKick, DrumChannel, 24)
+	if len(tr.events) != 5 { // 2 note-on/off pairs + end-of-track
+		t.Fatalf("len(events) = %d, want 5", len(tr.events))
+	}
+	if got, want := tr.events[0].tick, 0; got != want {
+		t.Errorf("first note-on tick = %d, want %d", got, want)
+	}
+	if got, want := tr.events[1].tick, 12; got != want {
+		t.Errorf("first note-off tick = %d, want %d", got, want)
+	}
+	if got, want := tr.events[len(tr.events)-1].tick, 72; got != want {
+		t.Errorf("end-of-track tick = %d, want %d", got, want)
+	}
+}
+
+func TestEncodeHeader(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTrack()
+	tr.EndOfTrack(0)
+	if err := Encode(&buf, []*Track{tr}, 96); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	data := buf.Bytes()
+	wantHeader := []byte("MThd\x00\x00\x00\x06\x00\x01\x00\x01\x00\x60")
+	if !bytes.Equal(data[:len(wantHeader)], wantHeader) {
+		t.Errorf("header = % X, want % X", data[:len(wantHeader)], wantHeader)
+	}
+
+	wantTrackID := []byte("MTrk")
+	if !bytes.Equal(data[len(wantHeader):len(wantHeader)+4], wantTrackID) {
+		t.Errorf("track chunk ID = %q, want %q", data[len(wantHeader):len(wantHeader)+4], wantTrackID)
+	}
+}
+
+func TestLCM(t *testing.T) {
+	cases := []struct{ a, b, want int }{
+		{8, 16, 16},
+		{8, 12, 24},
+		{3, 5, 15},
+		{4, 4, 4},
+	}
+	for _, c := range cases {
+		if got := lcm(c.a, c.b); got != c.want {
+			t.Errorf("lcm(%d, %d) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestTilePattern(t *testing.T) {
+	got := tilePattern([]int{1, 0, 0}, 8)
+	want := []int{1, 0, 0, 1, 0, 0, 1, 0}
+	if len(got) != len(want) {
+		t.Fatalf("tilePattern len = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("tilePattern[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
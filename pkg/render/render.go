@@ -0,0 +1,78 @@
+// Package render streams PCM audio out to a WAV encoder in fixed-size
+// blocks, so a caller rendering a long session never needs to hold the
+// entire mix in memory at once.
+package render
+
+import (
+	"io"
+
+	"github.com/go-audio/audio"
+	"github.com/go-audio/wav"
+)
+
+// defaultBlockFrames is used when Stream.BlockFrames is left at zero.
+const defaultBlockFrames = 4096
+
+// Fill computes one block of interleaved PCM samples for the frame range
+// [startFrame, startFrame+numFrames). block has numFrames*Channels
+// samples and arrives zeroed; Fill should add into it rather than assume
+// it is the only contributor, since callers may mix multiple sources into
+// the same block.
+type Fill func(startFrame, numFrames int, block []int)
+
+// Stream writes totalFrames frames of audio, one block at a time, by
+// repeatedly invoking a Fill function and reusing a single scratch buffer
+// across blocks.
+type Stream struct {
+	SampleRate  int
+	Channels    int
+	BitDepth    int // defaults to 16 if zero
+	BlockFrames int // defaults to 4096 if zero
+}
+
+// NewStream returns a Stream for 16-bit PCM at the given sample rate and
+// channel count, using the default block size.
+func NewStream(sampleRate, channels int) *Stream {
+	return &Stream{SampleRate: sampleRate, Channels: channels, BitDepth: 16}
+}
+
+// Write encodes totalFrames frames as a WAV file to w, calling fill once
+// per block to produce the samples. w must support Seek because the WAV
+// encoder back-patches the header once the final data size is known.
+func (s *Stream) Write(w io.WriteSeeker, totalFrames int, fill Fill) error {
+	bitDepth := s.BitDepth
+	if bitDepth == 0 {
+		bitDepth = 16
+	}
+	blockFrames := s.BlockFrames
+	if blockFrames <= 0 {
+		blockFrames = defaultBlockFrames
+	}
+
+	enc := wav.NewEncoder(w, s.SampleRate, bitDepth, s.Channels, 1)
+	scratch := make([]int, blockFrames*s.Channels)
+
+	for start := 0; start < totalFrames; start += blockFrames {
+		n := blockFrames
+		if start+n > totalFrames {
+			n = totalFrames - start
+		}
+		block := scratch[:n*s.Channels]
+		for i := range block {
+			block[i] = 0
+		}
+
+		fill(start, n, block)
+
+		buf := &audio.IntBuffer{
+			Data:           block,
+			Format:         &audio.Format{SampleRate: s.SampleRate, NumChannels: s.Channels},
+			SourceBitDepth: bitDepth,
+		}
+		if err := enc.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}
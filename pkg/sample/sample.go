@@ -0,0 +1,132 @@
+// Package sample loads folders of recorded hits into keyed dictionaries,
+// the "buffer dictionary" workflow familiar from SuperCollider, so a
+// Euclidean line can trigger real percussion samples instead of a
+// synthesized voice.
+package sample
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-audio/wav"
+)
+
+// Dictionary holds the decoded PCM buffers for every sample, keyed by the
+// name of the subdirectory they were loaded from (e.g. "kick", "snare").
+type Dictionary struct {
+	buffers map[string][][]int
+}
+
+// LoadDictionary walks dir and loads every .wav file found in its
+// immediate subdirectories, one key per subdirectory. A layout such as
+//
+//	dir/kick/01.wav
+//	dir/kick/02.wav
+//	dir/snare/hit.wav
+//
+// produces a Dictionary with keys "kick" (two buffers) and "snare" (one
+// buffer).
+func LoadDictionary(dir string) (*Dictionary, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dictionary{buffers: make(map[string][][]int)}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		key := entry.Name()
+		sub := filepath.Join(dir, key)
+		files, err := os.ReadDir(sub)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			if f.IsDir() || !strings.EqualFold(filepath.Ext(f.Name()), ".wav") {
+				continue
+			}
+			buf, err := loadWAV(filepath.Join(sub, f.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("sample: loading %s: %w", f.Name(), err)
+			}
+			d.buffers[key] = append(d.buffers[key], buf)
+		}
+	}
+	return d, nil
+}
+
+func loadWAV(path string) ([]int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := wav.NewDecoder(f)
+	buf, err := dec.FullPCMBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Data, nil
+}
+
+// Buffers returns every decoded buffer loaded for key, or nil if the
+// dictionary has no such key.
+func (d *Dictionary) Buffers(key string) [][]int {
+	return d.buffers[key]
+}
+
+// Voice plays samples picked from a Dictionary key, either randomly or by
+// cycling deterministically through the available buffers in order. It
+// satisfies seq.Voice without importing pkg/seq.
+type Voice struct {
+	buffers       [][]int
+	deterministic bool
+	rng           *rand.Rand
+	next          int
+}
+
+// NewVoice returns a Voice that picks a buffer from key each time it is
+// rendered. When deterministic is true the buffers are cycled through in
+// order (round-robin), which is useful for auditioning a dictionary or
+// keeping renders reproducible; otherwise a buffer is chosen at random.
+func NewVoice(dict *Dictionary, key string, deterministic bool) (*Voice, error) {
+	buffers := dict.Buffers(key)
+	if len(buffers) == 0 {
+		return nil, fmt.Errorf("sample: no buffers for key %q", key)
+	}
+	return &Voice{
+		buffers:       buffers,
+		deterministic: deterministic,
+		rng:           rand.New(rand.NewSource(1)),
+	}, nil
+}
+
+// Render adds one buffer from the dictionary into out starting at
+// startSample, per the seq.Voice contract.
+func (v *Voice) Render(startSample int, out []int) {
+	buf := v.pick()
+
+	bufStart := 0
+	if startSample < 0 {
+		bufStart = -startSample
+		startSample = 0
+	}
+	for i := 0; bufStart+i < len(buf) && startSample+i < len(out); i++ {
+		out[startSample+i] += buf[bufStart+i]
+	}
+}
+
+func (v *Voice) pick() []int {
+	if v.deterministic {
+		buf := v.buffers[v.next%len(v.buffers)]
+		v.next++
+		return buf
+	}
+	return v.buffers[v.rng.Intn(len(v.buffers))]
+}
@@ -0,0 +1,44 @@
+package sample
+
+import "testing"
+
+func TestVoiceDeterministicCyclesInOrder(t *testing.T) {
+	v := &Voice{
+		buffers:       [][]int{{1}, {2}, {3}},
+		deterministic: true,
+	}
+
+	out := make([]int, 1)
+	for _, want := range []int{1, 2, 3, 1, 2, 3} {
+		out[0] = 0
+		v.Render(0, out)
+		if out[0] != want {
+			t.Errorf("Render picked %d, want %d", out[0], want)
+		}
+	}
+}
+
+func TestVoiceRandomPicksWithinRange(t *testing.T) {
+	v, err := NewVoice(&Dictionary{buffers: map[string][][]int{
+		"kick": {{1}, {2}, {3}},
+	}}, "kick", false)
+	if err != nil {
+		t.Fatalf("NewVoice: %v", err)
+	}
+
+	out := make([]int, 1)
+	for i := 0; i < 50; i++ {
+		out[0] = 0
+		v.Render(0, out)
+		if out[0] < 1 || out[0] > 3 {
+			t.Fatalf("Render picked %d, want a value from the dictionary's 3 buffers", out[0])
+		}
+	}
+}
+
+func TestNewVoiceUnknownKey(t *testing.T) {
+	d := &Dictionary{buffers: map[string][][]int{}}
+	if _, err := NewVoice(d, "missing", false); err == nil {
+		t.Error("NewVoice(missing key): expected error, got nil")
+	}
+}
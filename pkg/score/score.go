@@ -0,0 +1,263 @@
+// Package score parses a small text format for multi-track Euclidean
+// rhythms and turns it into a ready-to-render seq.Sequencer, so patterns
+// can be prototyped in a text file instead of Go source.
+//
+// A score file is one directive per line:
+//
+//	bpm 120
+//	track kick euclid(3,8) rotate 0 voice sine 60Hz
+//	track snare euclid(2,8) rotate 4 voice noise
+//	track hat euclid(7,16) voice sine 8000Hz decay 20ms
+//
+// "bpm" sets the sequencer's master tempo. Each "track" line names a
+// track, gives its Euclidean pattern as euclid(pulses,steps), optionally
+// rotates it, and assigns it a voice: one of the drum presets ("kick",
+// "snare", "hat", "clap") or an oscillator ("sine", "square", "saw",
+// "triangle", "noise", "pink") with an optional frequency and a "decay"
+// in milliseconds. "gain" and "pan" are accepted on any track line.
+// Blank lines and lines starting with "#" are ignored.
+package score
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/luisgizirian/lab-audio/pkg/seq"
+	"github.com/luisgizirian/lab-audio/pkg/synth"
+)
+
+// sampleRate is fixed for every score, matching the rest of this project's
+// CLIs.
+const sampleRate = 44100
+
+// defaultBPM is used when a score has no "bpm" line.
+const defaultBPM = 120
+
+// defaultDecayMs is used when a track's voice has no explicit "decay".
+const defaultDecayMs = 80
+
+// Load reads the score file at path and returns a Sequencer ready to
+// render.
+func Load(path string) (*seq.Sequencer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(data))
+}
+
+// Parse builds a Sequencer from score source text.
+func Parse(src string) (*seq.Sequencer, error) {
+	bpm := defaultBPM
+	var tracks []seq.Track
+
+	for n, line := range strings.Split(src, "\n") {
+		lineNo := n + 1
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "bpm":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("score: line %d: expected \"bpm <n>\"", lineNo)
+			}
+			v, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("score: line %d: invalid bpm %q", lineNo, fields[1])
+			}
+			bpm = v
+		case "track":
+			t, err := parseTrack(fields)
+			if err != nil {
+				return nil, fmt.Errorf("score: line %d: %w", lineNo, err)
+			}
+			tracks = append(tracks, t)
+		default:
+			return nil, fmt.Errorf("score: line %d: unknown directive %q", lineNo, fields[0])
+		}
+	}
+
+	s := seq.NewSequencer(sampleRate, bpm)
+	for _, t := range tracks {
+		s.AddTrack(t)
+	}
+	return s, nil
+}
+
+// parseTrack parses the fields of a "track" line, everything after
+// "track" inclusive.
+func parseTrack(fields []string) (seq.Track, error) {
+	if len(fields) < 4 {
+		return seq.Track{}, fmt.Errorf("expected \"track <name> euclid(p,s) ... voice ...\"")
+	}
+
+	t := seq.Track{Name: fields[1]}
+	pulses, steps, err := parseEuclid(fields[2])
+	if err != nil {
+		return seq.Track{}, err
+	}
+	t.Pulses, t.Steps = pulses, steps
+
+	i := 3
+	if i < len(fields) && fields[i] == "rotate" {
+		if i+1 >= len(fields) {
+			return seq.Track{}, fmt.Errorf("\"rotate\" needs a value")
+		}
+		n, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			return seq.Track{}, fmt.Errorf("invalid rotate %q", fields[i+1])
+		}
+		t.Rotation = n
+		i += 2
+	}
+
+	if i >= len(fields) || fields[i] != "voice" {
+		return seq.Track{}, fmt.Errorf("expected \"voice <kind>\"")
+	}
+	i++
+	if i >= len(fields) {
+		return seq.Track{}, fmt.Errorf("\"voice\" needs a kind")
+	}
+	kind := fields[i]
+	i++
+
+	var freqHz float64
+	if isOscillatorKind(kind) && i < len(fields) && strings.HasSuffix(fields[i], "Hz") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(fields[i], "Hz"), 64)
+		if err != nil {
+			return seq.Track{}, fmt.Errorf("invalid frequency %q", fields[i])
+		}
+		freqHz = v
+		i++
+	}
+
+	decayMs := defaultDecayMs
+	for i < len(fields) {
+		switch fields[i] {
+		case "decay":
+			if i+1 >= len(fields) || !strings.HasSuffix(fields[i+1], "ms") {
+				return seq.Track{}, fmt.Errorf("\"decay\" needs a value like \"20ms\"")
+			}
+			v, err := strconv.Atoi(strings.TrimSuffix(fields[i+1], "ms"))
+			if err != nil {
+				return seq.Track{}, fmt.Errorf("invalid decay %q", fields[i+1])
+			}
+			decayMs = v
+			i += 2
+		case "gain":
+			if i+1 >= len(fields) {
+				return seq.Track{}, fmt.Errorf("\"gain\" needs a value")
+			}
+			v, err := strconv.ParseFloat(fields[i+1], 64)
+			if err != nil {
+				return seq.Track{}, fmt.Errorf("invalid gain %q", fields[i+1])
+			}
+			t.Gain = v
+			i += 2
+		case "pan":
+			if i+1 >= len(fields) {
+				return seq.Track{}, fmt.Errorf("\"pan\" needs a value")
+			}
+			v, err := strconv.ParseFloat(fields[i+1], 64)
+			if err != nil {
+				return seq.Track{}, fmt.Errorf("invalid pan %q", fields[i+1])
+			}
+			t.Pan = v
+			i += 2
+		default:
+			return seq.Track{}, fmt.Errorf("unexpected %q", fields[i])
+		}
+	}
+
+	voice, err := newVoice(kind, freqHz, decayMs)
+	if err != nil {
+		return seq.Track{}, err
+	}
+	t.Voice = voice
+	return t, nil
+}
+
+// parseEuclid parses a "euclid(pulses,steps)" token.
+func parseEuclid(field string) (pulses, steps int, err error) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(field, "euclid("), ")")
+	if inner == field {
+		return 0, 0, fmt.Errorf("expected \"euclid(p,s)\", got %q", field)
+	}
+	parts := strings.Split(inner, ",")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected \"euclid(p,s)\", got %q", field)
+	}
+	pulses, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid pulses in %q", field)
+	}
+	steps, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid steps in %q", field)
+	}
+	if steps <= 0 {
+		return 0, 0, fmt.Errorf("steps must be positive in %q", field)
+	}
+	if pulses < 0 || pulses > steps {
+		return 0, 0, fmt.Errorf("pulses must be between 0 and steps in %q", field)
+	}
+	return pulses, steps, nil
+}
+
+func isOscillatorKind(kind string) bool {
+	switch kind {
+	case "sine", "square", "saw", "triangle":
+		return true
+	default:
+		return false
+	}
+}
+
+// newVoice builds the seq.Voice named by kind: one of the drum presets,
+// or an oscillator rendered for decayMs with an ADSR envelope shaped by
+// that decay.
+func newVoice(kind string, freqHz float64, decayMs int) (seq.Voice, error) {
+	switch kind {
+	case "kick":
+		return synth.NewKick(sampleRate), nil
+	case "snare":
+		return synth.NewSnare(sampleRate), nil
+	case "hat":
+		return synth.NewHat(sampleRate), nil
+	case "clap":
+		return synth.NewClap(sampleRate), nil
+	case "sine", "square", "saw", "triangle", "noise", "pink":
+		osc := oscillatorFor(kind)
+		env := synth.ADSR{
+			AttackMs:     1,
+			DecayMs:      float64(decayMs) * 0.8,
+			SustainLevel: 0,
+			ReleaseMs:    float64(decayMs) * 0.2,
+		}
+		return synth.NewVoice(osc, freqHz, sampleRate, decayMs, env, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown voice %q", kind)
+	}
+}
+
+func oscillatorFor(kind string) synth.Oscillator {
+	switch kind {
+	case "square":
+		return synth.Square
+	case "saw":
+		return synth.Saw
+	case "triangle":
+		return synth.Triangle
+	case "noise":
+		return synth.WhiteNoise
+	case "pink":
+		return synth.PinkNoise
+	default:
+		return synth.Sine
+	}
+}
@@ -0,0 +1,84 @@
+package score
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	src := `
+# a tresillo over a backbeat
+bpm 140
+track kick euclid(3,8) rotate 0 voice sine 60Hz
+track snare euclid(2,8) rotate 4 voice noise gain 0.8 pan -0.5
+`
+	s, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if s.BPM != 140 {
+		t.Errorf("BPM = %d, want 140", s.BPM)
+	}
+	if s.SampleRate != sampleRate {
+		t.Errorf("SampleRate = %d, want %d", s.SampleRate, sampleRate)
+	}
+	if len(s.Tracks) != 2 {
+		t.Fatalf("len(Tracks) = %d, want 2", len(s.Tracks))
+	}
+
+	kick := s.Tracks[0]
+	if kick.Name != "kick" || kick.Pulses != 3 || kick.Steps != 8 || kick.Rotation != 0 {
+		t.Errorf("kick track = %+v", kick)
+	}
+	if kick.Voice == nil {
+		t.Error("kick track has no voice")
+	}
+
+	snare := s.Tracks[1]
+	if snare.Name != "snare" || snare.Pulses != 2 || snare.Steps != 8 || snare.Rotation != 4 {
+		t.Errorf("snare track = %+v", snare)
+	}
+	if snare.Gain != 0.8 || snare.Pan != -0.5 {
+		t.Errorf("snare gain/pan = %v/%v, want 0.8/-0.5", snare.Gain, snare.Pan)
+	}
+}
+
+func TestParseDefaultBPM(t *testing.T) {
+	s, err := Parse("track hat euclid(7,16) voice hat\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if s.BPM != defaultBPM {
+		t.Errorf("BPM = %d, want default %d", s.BPM, defaultBPM)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []string{
+		"bpm\n",
+		"bpm notanumber\n",
+		"track kick voice sine\n",
+		"track kick euclid(3,8) voice\n",
+		"track kick euclid(3,8) voice unknown\n",
+		"track kick euclid(3) voice sine\n",
+		"track kick euclid(9,8) voice kick\n",
+		"track kick euclid(-2,8) voice kick\n",
+		"nonsense line\n",
+	}
+	for _, src := range cases {
+		if _, err := Parse(src); err == nil {
+			t.Errorf("Parse(%q): expected error, got nil", src)
+		}
+	}
+}
+
+func TestParseEuclid(t *testing.T) {
+	pulses, steps, err := parseEuclid("euclid(3,8)")
+	if err != nil {
+		t.Fatalf("parseEuclid: %v", err)
+	}
+	if pulses != 3 || steps != 8 {
+		t.Errorf("parseEuclid(\"euclid(3,8)\") = (%d, %d), want (3, 8)", pulses, steps)
+	}
+
+	if _, _, err := parseEuclid("not-euclid"); err == nil {
+		t.Error("parseEuclid(\"not-euclid\"): expected error, got nil")
+	}
+}
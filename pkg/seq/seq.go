@@ -0,0 +1,253 @@
+// Package seq lays out multiple Euclidean rhythm tracks against a shared
+// cycle and mixes them down into a single stereo WAV stream.
+//
+// A Sequencer holds a master tempo and a set of Track values, each with its
+// own step count, pulse count, rotation, and voice. Tracks with different
+// step counts share a common cycle length equal to the LCM of their step
+// counts, so patterns like a 3-over-8 tresillo and a 7-over-12 bell line
+// resolve back to the same downbeat.
+package seq
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"github.com/luisgizirian/lab-audio/pkg/euclid"
+	"github.com/luisgizirian/lab-audio/pkg/render"
+)
+
+// defaultBlockFrames is used when Sequencer.BlockFrames is left at zero.
+const defaultBlockFrames = 4096
+
+// Voice renders the audio for a single hit. Render adds its samples into
+// out starting at out[startSample], accumulating onto whatever is already
+// there rather than overwriting it, since multiple hits and tracks can
+// overlap within the same render block. startSample may be negative when
+// a hit began in an earlier block but is still sounding; implementations
+// should skip the samples before index 0 in that case instead of panicking.
+type Voice interface {
+	Render(startSample int, out []int)
+}
+
+// Track is one Euclidean rhythm line in a Sequencer.
+type Track struct {
+	Name     string  // human-readable label, used only for diagnostics
+	Steps    int     // total steps in this track's Euclidean pattern
+	Pulses   int     // number of hits distributed across Steps
+	Rotation int     // cyclic rotation applied to the pattern, see euclid.Pattern.Rotate
+	BPM      int     // per-track tempo override; 0 uses the Sequencer's master BPM
+	Voice    Voice   // renders the sound played at each hit
+	Gain     float64 // linear gain multiplier; 0 is treated as unity gain
+	Pan      float64 // stereo position from -1 (left) to 1 (right), 0 is center
+}
+
+// Sequencer mixes a set of Tracks into a single stereo audio stream at a
+// shared master tempo.
+type Sequencer struct {
+	SampleRate  int
+	BPM         int
+	Loops       int // number of times to repeat the shared cycle; 0 or 1 renders one cycle
+	BlockFrames int // render block size in frames; 0 uses a sensible default
+	Tracks      []Track
+}
+
+// NewSequencer creates a Sequencer with the given sample rate and master
+// BPM. Tracks are added afterwards with AddTrack.
+func NewSequencer(sampleRate, bpm int) *Sequencer {
+	return &Sequencer{SampleRate: sampleRate, BPM: bpm, Loops: 1}
+}
+
+// AddTrack appends a track to the sequence.
+func (s *Sequencer) AddTrack(t Track) {
+	s.Tracks = append(s.Tracks, t)
+}
+
+// renderTrack is the precomputed, per-render state for a single Track.
+type renderTrack struct {
+	pattern        euclid.Pattern
+	stepSamples    int
+	patternSamples int
+	voice          Voice
+	gain           float64
+	pan            float64
+}
+
+// RenderTo mixes every track down to stereo 16-bit PCM and streams it to w
+// as a WAV file, one fixed-size block of frames at a time so that long,
+// many-track sessions never require the entire mix to be held in memory.
+// w must support Seek because the WAV encoder back-patches the header
+// once the final data size is known; an *os.File satisfies this.
+func (s *Sequencer) RenderTo(w io.WriteSeeker) error {
+	if len(s.Tracks) == 0 {
+		return errors.New("seq: sequencer has no tracks")
+	}
+	if s.SampleRate <= 0 {
+		return errors.New("seq: sample rate must be positive")
+	}
+	if s.BPM <= 0 {
+		return errors.New("seq: BPM must be positive")
+	}
+	for _, t := range s.Tracks {
+		if t.BPM < 0 {
+			return errors.New("seq: track BPM must not be negative")
+		}
+		if t.Steps <= 0 {
+			return errors.New("seq: track Steps must be positive")
+		}
+		if t.Pulses < 0 || t.Pulses > t.Steps {
+			return errors.New("seq: track Pulses must be between 0 and Steps")
+		}
+	}
+
+	cycleSteps := 1
+	for _, t := range s.Tracks {
+		cycleSteps = lcm(cycleSteps, t.Steps)
+	}
+
+	loops := s.Loops
+	if loops <= 0 {
+		loops = 1
+	}
+	masterStepSamples := s.SampleRate * 60 / s.BPM
+	totalSamples := cycleSteps * masterStepSamples * loops
+
+	tracks := make([]renderTrack, len(s.Tracks))
+	for i, t := range s.Tracks {
+		bpm := t.BPM
+		if bpm == 0 {
+			bpm = s.BPM
+		}
+		gain := t.Gain
+		if gain == 0 {
+			gain = 1
+		}
+		pattern := euclid.Bjorklund(t.Steps, t.Pulses).Rotate(t.Rotation)
+		stepSamples := s.SampleRate * 60 / bpm
+
+		tracks[i] = renderTrack{
+			pattern:        pattern,
+			stepSamples:    stepSamples,
+			patternSamples: len(pattern) * stepSamples,
+			voice:          t.Voice,
+			gain:           gain,
+			pan:            t.Pan,
+		}
+	}
+
+	blockFrames := s.BlockFrames
+	if blockFrames <= 0 {
+		blockFrames = defaultBlockFrames
+	}
+	mono := make([]int, blockFrames)
+
+	stream := render.Stream{SampleRate: s.SampleRate, Channels: 2, BlockFrames: blockFrames}
+	return stream.Write(w, totalSamples, func(start, n int, block []int) {
+		mixBlock(tracks, block, mono[:n], start, n, blockFrames)
+	})
+}
+
+// mixBlock renders every active hit that falls in or rings into the frame
+// range [start, start+n) into block, an interleaved stereo buffer of n
+// frames, clamping the result to 16-bit PCM range. mono is reused scratch
+// space for each track's one-shot render.
+func mixBlock(tracks []renderTrack, block, mono []int, start, n, blockFrames int) {
+	for _, tr := range tracks {
+		if tr.voice == nil || tr.patternSamples == 0 {
+			continue
+		}
+		left, right := panGains(tr.pan)
+		stepsPerPattern := tr.patternSamples / tr.stepSamples
+
+		// Hits that started during the previous block may still be
+		// ringing out into this one, so look one block further back
+		// than the window being rendered.
+		lookback := start - blockFrames
+		if lookback < 0 {
+			lookback = 0
+		}
+		firstStep := lookback / tr.stepSamples
+		lastStep := (start + n - 1) / tr.stepSamples
+		for step := firstStep; step <= lastStep; step++ {
+			if tr.pattern[step%stepsPerPattern] == 0 {
+				continue
+			}
+			hitStart := step * tr.stepSamples
+			rel := hitStart - start
+			if rel >= n {
+				continue
+			}
+
+			for i := range mono {
+				mono[i] = 0
+			}
+			tr.voice.Render(rel, mono)
+
+			_ = block[len(mono)*2-1] // bounds-check hint: block holds 2 samples per mono frame
+			for i, v := range mono {
+				if v == 0 {
+					continue
+				}
+				sample := float64(v) * tr.gain
+				block[i*2] += int(sample * left)
+				block[i*2+1] += int(sample * right)
+			}
+		}
+	}
+
+	for i, v := range block {
+		block[i] = clamp16(v)
+	}
+}
+
+// maxSample16 and minSample16 are the bounds of a 16-bit signed PCM
+// sample, the range every mixed block is clamped to before it reaches
+// the WAV encoder.
+const (
+	maxSample16 = 32767
+	minSample16 = -32768
+)
+
+// clamp16 clamps v to the 16-bit PCM range. Summing several tracks' gain-
+// scaled hits into one block can easily push a sample outside +-32767;
+// without clamping here, the encoder's raw int16 conversion wraps instead
+// of saturating, which sounds like digital distortion rather than a
+// louder mix.
+func clamp16(v int) int {
+	if v > maxSample16 {
+		return maxSample16
+	}
+	if v < minSample16 {
+		return minSample16
+	}
+	return v
+}
+
+// panGains converts a -1..1 pan position into equal-power left/right gain
+// coefficients.
+func panGains(pan float64) (left, right float64) {
+	if pan < -1 {
+		pan = -1
+	}
+	if pan > 1 {
+		pan = 1
+	}
+	angle := (pan + 1) / 2 * (math.Pi / 2)
+	return math.Cos(angle), math.Sin(angle)
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// lcm returns the least common multiple of a and b.
+func lcm(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return a / gcd(a, b) * b
+}
@@ -0,0 +1,137 @@
+package seq
+
+import (
+	"math"
+	"testing"
+
+	"github.com/luisgizirian/lab-audio/pkg/euclid"
+)
+
+// impulseVoice renders a single fixed-amplitude sample at startSample,
+// the simplest stand-in for a real Voice that still exercises mixBlock's
+// placement and clamping logic.
+type impulseVoice struct {
+	amplitude int
+}
+
+func (v impulseVoice) Render(startSample int, out []int) {
+	if startSample >= 0 && startSample < len(out) {
+		out[startSample] += v.amplitude
+	}
+}
+
+func TestMixBlockPlacesHitAtCorrectSample(t *testing.T) {
+	tr := renderTrack{
+		pattern:        euclid.Pattern{1, 0},
+		stepSamples:    4,
+		patternSamples: 8,
+		voice:          impulseVoice{amplitude: 1000},
+		gain:           1,
+		pan:            0,
+	}
+
+	block := make([]int, 8) // 4 frames, stereo
+	mono := make([]int, 4)
+	mixBlock([]renderTrack{tr}, block, mono, 0, 4, 4)
+
+	left, right := panGains(0)
+	wantL := int(1000 * left)
+	wantR := int(1000 * right)
+	if block[0] != wantL || block[1] != wantR {
+		t.Errorf("block[0:2] = [%d, %d], want [%d, %d]", block[0], block[1], wantL, wantR)
+	}
+	// The rest of the pattern is silent (pattern[1] == 0), so no other
+	// frame should have been touched.
+	for i := 2; i < len(block); i++ {
+		if block[i] != 0 {
+			t.Errorf("block[%d] = %d, want 0", i, block[i])
+		}
+	}
+}
+
+func TestMixBlockClampsToPCMRange(t *testing.T) {
+	tr := renderTrack{
+		pattern:        euclid.Pattern{1},
+		stepSamples:    4,
+		patternSamples: 4,
+		voice:          impulseVoice{amplitude: 100000},
+		gain:           1,
+		pan:            0,
+	}
+
+	block := make([]int, 8)
+	mono := make([]int, 4)
+	mixBlock([]renderTrack{tr}, block, mono, 0, 4, 4)
+
+	for i, v := range block {
+		if v > maxSample16 || v < minSample16 {
+			t.Errorf("block[%d] = %d, out of 16-bit PCM range", i, v)
+		}
+	}
+	if block[0] != maxSample16 {
+		t.Errorf("block[0] = %d, want clamped to %d", block[0], maxSample16)
+	}
+}
+
+func TestMixBlockNegativeClamps(t *testing.T) {
+	tr := renderTrack{
+		pattern:        euclid.Pattern{1},
+		stepSamples:    4,
+		patternSamples: 4,
+		voice:          impulseVoice{amplitude: -100000},
+		gain:           1,
+		pan:            0,
+	}
+
+	block := make([]int, 8)
+	mono := make([]int, 4)
+	mixBlock([]renderTrack{tr}, block, mono, 0, 4, 4)
+
+	if block[0] != minSample16 {
+		t.Errorf("block[0] = %d, want clamped to %d", block[0], minSample16)
+	}
+}
+
+func TestPanGains(t *testing.T) {
+	left, right := panGains(0)
+	if math.Abs(left-right) > 1e-9 {
+		t.Errorf("center pan: left=%v right=%v, want equal", left, right)
+	}
+	left, right = panGains(-1)
+	if right > 1e-9 {
+		t.Errorf("hard-left pan: right=%v, want ~0", right)
+	}
+	if math.Abs(left-1) > 1e-9 {
+		t.Errorf("hard-left pan: left=%v, want ~1", left)
+	}
+}
+
+func TestLCM(t *testing.T) {
+	cases := []struct{ a, b, want int }{
+		{8, 16, 16},
+		{8, 12, 24},
+		{3, 5, 15},
+	}
+	for _, c := range cases {
+		if got := lcm(c.a, c.b); got != c.want {
+			t.Errorf("lcm(%d, %d) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestRenderToRejectsInvalidTempoAndTrack(t *testing.T) {
+	cases := []struct {
+		name string
+		s    *Sequencer
+	}{
+		{"zero BPM", &Sequencer{SampleRate: 44100, BPM: 0, Tracks: []Track{{Steps: 8, Pulses: 3}}}},
+		{"zero sample rate", &Sequencer{SampleRate: 0, BPM: 120, Tracks: []Track{{Steps: 8, Pulses: 3}}}},
+		{"pulses > steps", &Sequencer{SampleRate: 44100, BPM: 120, Tracks: []Track{{Steps: 8, Pulses: 9}}}},
+		{"negative pulses", &Sequencer{SampleRate: 44100, BPM: 120, Tracks: []Track{{Steps: 8, Pulses: -1}}}},
+	}
+	for _, c := range cases {
+		if err := c.s.RenderTo(nil); err == nil {
+			t.Errorf("%s: RenderTo returned nil error, want a validation error", c.name)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package synth
+
+// ADSR is a standard attack/decay/sustain/release envelope. AttackMs,
+// DecayMs, and ReleaseMs are durations in milliseconds; SustainLevel is
+// the amplitude (0..1) held between the decay and release stages.
+type ADSR struct {
+	AttackMs     float64
+	DecayMs      float64
+	SustainLevel float64
+	ReleaseMs    float64
+}
+
+// Envelope renders the envelope as totalSamples amplitude values in
+// 0..1. When the attack, decay, and release stages don't fit within
+// totalSamples, the sustain stage is simply skipped; percussive hits are
+// usually shorter than AttackMs+DecayMs+ReleaseMs would otherwise allow.
+func (e ADSR) Envelope(sampleRate, totalSamples int) []float64 {
+	attack := msToSamples(sampleRate, e.AttackMs)
+	decay := msToSamples(sampleRate, e.DecayMs)
+	release := msToSamples(sampleRate, e.ReleaseMs)
+
+	sustain := totalSamples - attack - decay - release
+	if sustain < 0 {
+		sustain = 0
+	}
+
+	env := make([]float64, totalSamples)
+	for i := range env {
+		switch {
+		case i < attack:
+			env[i] = rampUp(i, attack)
+		case i < attack+decay:
+			env[i] = 1 - rampUp(i-attack, decay)*(1-e.SustainLevel)
+		case i < attack+decay+sustain:
+			env[i] = e.SustainLevel
+		default:
+			env[i] = e.SustainLevel * (1 - rampUp(i-attack-decay-sustain, release))
+		}
+	}
+	return env
+}
+
+// rampUp returns i/n clamped to [0, 1], treating n <= 0 as an instantaneous
+// ramp to 1.
+func rampUp(i, n int) float64 {
+	if n <= 0 {
+		return 1
+	}
+	t := float64(i) / float64(n)
+	if t > 1 {
+		t = 1
+	}
+	return t
+}
+
+func msToSamples(sampleRate int, ms float64) int {
+	return int(float64(sampleRate) * ms / 1000)
+}
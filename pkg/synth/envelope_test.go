@@ -0,0 +1,59 @@
+package synth
+
+import "testing"
+
+func TestADSREnvelopeStages(t *testing.T) {
+	// 10 samples attack, 10 decay, 10 sustain, 10 release at 1000Hz
+	// (1ms == 1 sample), easy to reason about by index.
+	e := ADSR{AttackMs: 10, DecayMs: 10, SustainLevel: 0.5, ReleaseMs: 10}
+	env := e.Envelope(1000, 40)
+
+	if len(env) != 40 {
+		t.Fatalf("len(env) = %d, want 40", len(env))
+	}
+	if env[0] != 0 {
+		t.Errorf("env[0] = %v, want 0 (start of attack)", env[0])
+	}
+	if env[9] <= env[0] || env[9] > 1 {
+		t.Errorf("env[9] = %v, want close to 1 at end of attack", env[9])
+	}
+	if env[19] < 0.5-0.05 || env[19] > 0.55 {
+		t.Errorf("env[19] = %v, want close to sustain level 0.5 at end of decay", env[19])
+	}
+	if env[20] != 0.5 || env[29] != 0.5 {
+		t.Errorf("env[20]=%v env[29]=%v, want sustain level 0.5 throughout the sustain stage", env[20], env[29])
+	}
+	if env[39] >= env[30] {
+		t.Errorf("env[39] = %v, want lower than env[30] = %v (release ramps down)", env[39], env[30])
+	}
+}
+
+func TestADSREnvelopeShortBufferSkipsSustain(t *testing.T) {
+	// Attack+decay+release alone already exceed totalSamples, so the
+	// sustain stage should be skipped entirely rather than go negative.
+	e := ADSR{AttackMs: 10, DecayMs: 10, SustainLevel: 1, ReleaseMs: 10}
+	env := e.Envelope(1000, 15)
+	if len(env) != 15 {
+		t.Fatalf("len(env) = %d, want 15", len(env))
+	}
+	for i, v := range env {
+		if v < 0 || v > 1 {
+			t.Errorf("env[%d] = %v, out of [0,1] range", i, v)
+		}
+	}
+}
+
+func TestRampUp(t *testing.T) {
+	if got := rampUp(0, 10); got != 0 {
+		t.Errorf("rampUp(0, 10) = %v, want 0", got)
+	}
+	if got := rampUp(10, 10); got != 1 {
+		t.Errorf("rampUp(10, 10) = %v, want 1", got)
+	}
+	if got := rampUp(5, 0); got != 1 {
+		t.Errorf("rampUp(5, 0) = %v, want 1 (instantaneous ramp)", got)
+	}
+	if got := rampUp(100, 10); got != 1 {
+		t.Errorf("rampUp(100, 10) = %v, want clamped to 1", got)
+	}
+}
@@ -0,0 +1,69 @@
+package synth
+
+import "math"
+
+// FilterKind selects the response shape a Biquad implements.
+type FilterKind int
+
+const (
+	LowPass FilterKind = iota
+	HighPass
+	BandPass
+)
+
+// Biquad is a second-order IIR filter following the standard direct-form-I
+// recurrence
+//
+//	y[n] = (b0*x[n] + b1*x[n-1] + b2*x[n-2] - a1*y[n-1] - a2*y[n-2]) / a0
+//
+// with coefficients computed from the RBJ audio cookbook formulas. Higher
+// Q values produce a sharper resonant peak at cutoff, which is what gives
+// a filtered low-frequency sine its percussive "body".
+type Biquad struct {
+	b0, b1, b2, a0, a1, a2 float64
+	x1, x2, y1, y2         float64
+}
+
+// NewBiquad builds a Biquad of the given kind for cutoff (Hz) and
+// resonance Q.
+func NewBiquad(kind FilterKind, sampleRate int, cutoff, q float64) *Biquad {
+	w0 := 2 * math.Pi * cutoff / float64(sampleRate)
+	cosw0 := math.Cos(w0)
+	alpha := math.Sin(w0) / (2 * q)
+
+	f := &Biquad{a0: 1 + alpha, a1: -2 * cosw0, a2: 1 - alpha}
+	switch kind {
+	case HighPass:
+		f.b0 = (1 + cosw0) / 2
+		f.b1 = -(1 + cosw0)
+		f.b2 = (1 + cosw0) / 2
+	case BandPass:
+		f.b0 = alpha
+		f.b1 = 0
+		f.b2 = -alpha
+	default: // LowPass, including the resonant case (high Q)
+		f.b0 = (1 - cosw0) / 2
+		f.b1 = 1 - cosw0
+		f.b2 = (1 - cosw0) / 2
+	}
+	return f
+}
+
+// Process filters in, returning a new slice of the same length. The
+// filter's internal state carries over between calls, so feeding it
+// consecutive chunks of the same signal is equivalent to filtering it in
+// one pass.
+func (f *Biquad) Process(in []float64) []float64 {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]float64, len(in))
+	_ = out[len(in)-1] // bounds-check hint: out and in are the same length
+	for i, x := range in {
+		y := (f.b0*x + f.b1*f.x1 + f.b2*f.x2 - f.a1*f.y1 - f.a2*f.y2) / f.a0
+		f.x2, f.x1 = f.x1, x
+		f.y2, f.y1 = f.y1, y
+		out[i] = y
+	}
+	return out
+}
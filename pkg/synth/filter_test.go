@@ -0,0 +1,72 @@
+package synth
+
+import (
+	"math"
+	"testing"
+)
+
+// constantInput returns n samples all equal to v, used to drive a filter
+// to its DC steady state.
+func constantInput(v float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+func TestBiquadLowPassPassesDC(t *testing.T) {
+	f := NewBiquad(LowPass, 44100, 200, 0.707)
+	out := f.Process(constantInput(1, 2000))
+	// A low-pass filter's DC gain is 1, so a constant input should settle
+	// back to (close to) its original amplitude once the filter's
+	// transient has died out.
+	if got := out[len(out)-1]; math.Abs(got-1) > 0.01 {
+		t.Errorf("low-pass steady-state DC output = %v, want close to 1", got)
+	}
+}
+
+func TestBiquadHighPassBlocksDC(t *testing.T) {
+	f := NewBiquad(HighPass, 44100, 200, 0.707)
+	out := f.Process(constantInput(1, 2000))
+	// A high-pass filter's DC gain is 0, so a constant input should decay
+	// to (close to) zero.
+	if got := out[len(out)-1]; math.Abs(got) > 0.01 {
+		t.Errorf("high-pass steady-state DC output = %v, want close to 0", got)
+	}
+}
+
+func TestBiquadProcessPreservesLength(t *testing.T) {
+	f := NewBiquad(BandPass, 44100, 1000, 1)
+	in := make([]float64, 100)
+	if got := len(f.Process(in)); got != len(in) {
+		t.Errorf("len(Process(in)) = %d, want %d", got, len(in))
+	}
+	if got := f.Process(nil); got != nil {
+		t.Errorf("Process(nil) = %v, want nil", got)
+	}
+}
+
+func TestBiquadStateCarriesAcrossCalls(t *testing.T) {
+	// Filtering one long buffer should be equivalent to filtering the
+	// same signal split across two consecutive calls, since Process
+	// carries its internal state between calls.
+	whole := NewBiquad(LowPass, 44100, 500, 0.707)
+	signal := constantInput(1, 200)
+	wantAll := whole.Process(signal)
+
+	split := NewBiquad(LowPass, 44100, 500, 0.707)
+	gotFirst := split.Process(signal[:100])
+	gotSecond := split.Process(signal[100:])
+
+	for i, v := range gotFirst {
+		if math.Abs(v-wantAll[i]) > 1e-9 {
+			t.Errorf("split[%d] = %v, want %v", i, v, wantAll[i])
+		}
+	}
+	for i, v := range gotSecond {
+		if math.Abs(v-wantAll[100+i]) > 1e-9 {
+			t.Errorf("split[%d] = %v, want %v", 100+i, v, wantAll[100+i])
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package synth
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Oscillator generates n samples of a waveform in the range [-1, 1] at the
+// given sample rate and frequency. Noise oscillators ignore freq.
+type Oscillator func(sampleRate int, freq float64, n int) []float64
+
+// noiseRand backs WhiteNoise and PinkNoise. It is seeded deterministically
+// so renders are reproducible run to run.
+var noiseRand = rand.New(rand.NewSource(1))
+
+// Sine generates a sine wave.
+func Sine(sampleRate int, freq float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.Sin(2 * math.Pi * freq * float64(i) / float64(sampleRate))
+	}
+	return out
+}
+
+// Square generates a square wave alternating between -1 and 1.
+func Square(sampleRate int, freq float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		phase := math.Mod(freq*float64(i)/float64(sampleRate), 1)
+		if phase < 0.5 {
+			out[i] = 1
+		} else {
+			out[i] = -1
+		}
+	}
+	return out
+}
+
+// Saw generates a sawtooth wave ramping from -1 to 1 each cycle.
+func Saw(sampleRate int, freq float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		phase := math.Mod(freq*float64(i)/float64(sampleRate), 1)
+		out[i] = 2*phase - 1
+	}
+	return out
+}
+
+// Triangle generates a triangle wave.
+func Triangle(sampleRate int, freq float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		phase := math.Mod(freq*float64(i)/float64(sampleRate), 1)
+		out[i] = 2*math.Abs(2*phase-1) - 1
+	}
+	return out
+}
+
+// WhiteNoise generates uniform random noise.
+func WhiteNoise(sampleRate int, freq float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = noiseRand.Float64()*2 - 1
+	}
+	return out
+}
+
+// PinkNoise generates noise with a -3dB/octave spectral slope using Paul
+// Kellet's refined running-sum approximation of a 1/f filter.
+func PinkNoise(sampleRate int, freq float64, n int) []float64 {
+	var b0, b1, b2, b3, b4, b5, b6 float64
+	out := make([]float64, n)
+	for i := range out {
+		white := noiseRand.Float64()*2 - 1
+		b0 = 0.99886*b0 + white*0.0555179
+		b1 = 0.99332*b1 + white*0.0750759
+		b2 = 0.96900*b2 + white*0.1538520
+		b3 = 0.86650*b3 + white*0.3104856
+		b4 = 0.55000*b4 + white*0.5329522
+		b5 = -0.7616*b5 - white*0.0168980
+		pink := b0 + b1 + b2 + b3 + b4 + b5 + b6 + white*0.5362
+		b6 = white * 0.115926
+		out[i] = pink * 0.11
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package synth
+
+// NewKick builds a low sine tone pushed through a resonant low-pass
+// filter, the combination that gives a kick drum its percussive "body".
+func NewKick(sampleRate int) *SynthVoice {
+	filter := NewBiquad(LowPass, sampleRate, 90, 3.5)
+	env := ADSR{AttackMs: 1, DecayMs: 160, SustainLevel: 0, ReleaseMs: 40}
+	return &SynthVoice{buf: Render(Sine, 55, sampleRate, 220, env, filter)}
+}
+
+// NewSnare layers a band-passed noise burst (the snare wires) over a
+// triangle tone (the drum body).
+func NewSnare(sampleRate int) *SynthVoice {
+	noiseFilter := NewBiquad(BandPass, sampleRate, 1800, 1.2)
+	noiseEnv := ADSR{AttackMs: 1, DecayMs: 100, SustainLevel: 0, ReleaseMs: 20}
+	noise := Render(WhiteNoise, 0, sampleRate, 150, noiseEnv, noiseFilter)
+
+	toneEnv := ADSR{AttackMs: 1, DecayMs: 60, SustainLevel: 0, ReleaseMs: 10}
+	tone := Render(Triangle, 180, sampleRate, 150, toneEnv, nil)
+
+	return &SynthVoice{buf: mix(noise, tone)}
+}
+
+// NewHat builds a short, bright burst of high-passed noise.
+func NewHat(sampleRate int) *SynthVoice {
+	filter := NewBiquad(HighPass, sampleRate, 7000, 0.9)
+	env := ADSR{AttackMs: 0.5, DecayMs: 35, SustainLevel: 0, ReleaseMs: 10}
+	return &SynthVoice{buf: Render(WhiteNoise, 0, sampleRate, 60, env, filter)}
+}
+
+// NewClap approximates a hand clap as three band-passed noise bursts
+// fired in quick succession, the "flam" that distinguishes a clap from a
+// single noise hit.
+func NewClap(sampleRate int) *SynthVoice {
+	filter := NewBiquad(BandPass, sampleRate, 1200, 1.0)
+	env := ADSR{AttackMs: 1, DecayMs: 70, SustainLevel: 0, ReleaseMs: 20}
+	burst := Render(WhiteNoise, 0, sampleRate, 90, env, filter)
+
+	flamGapMs := 12
+	gap := msToSamples(sampleRate, float64(flamGapMs))
+	buf := make([]int, len(burst)+2*gap)
+	for rep := 0; rep < 3; rep++ {
+		offset := rep * gap
+		for i, s := range burst {
+			if offset+i >= len(buf) {
+				break
+			}
+			buf[offset+i] += s
+		}
+	}
+	for i, s := range buf {
+		buf[i] = clamp16(s)
+	}
+	return &SynthVoice{buf: buf}
+}
+
+// mix sums two buffers sample-by-sample, padding the shorter one with
+// silence, and clamps the result back into 16-bit PCM range.
+func mix(a, b []int) []int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	out := make([]int, n)
+	copy(out, a)
+	for i, s := range b {
+		out[i] += s
+	}
+	for i, s := range out {
+		out[i] = clamp16(s)
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+// Package synth builds percussive one-shot sounds from composable
+// oscillators, an ADSR envelope, and biquad filters, for use as the voice
+// behind a Euclidean rhythm.
+package synth
+
+// maxSample16 and minSample16 are the bounds of a 16-bit signed PCM
+// sample, the range every buffer in this package is clamped to before it
+// can reach the WAV encoder.
+const (
+	maxSample16 = 32767
+	minSample16 = -32768
+)
+
+// clamp16 clamps v to the 16-bit PCM range. Resonant filters and summed
+// voices can easily push a sample outside ±32767; without clamping here,
+// the encoder's raw int16 conversion wraps instead of saturating, which
+// sounds like digital distortion rather than a louder hit.
+func clamp16(v int) int {
+	if v > maxSample16 {
+		return maxSample16
+	}
+	if v < minSample16 {
+		return minSample16
+	}
+	return v
+}
+
+// SynthVoice plays a fixed one-shot buffer at every hit. It satisfies
+// seq.Voice without importing pkg/seq.
+type SynthVoice struct {
+	buf []int
+}
+
+// Samples returns the voice's rendered one-shot buffer as 16-bit PCM
+// samples, for callers that want the raw audio rather than triggering it
+// through the Voice interface.
+func (v *SynthVoice) Samples() []int {
+	return v.buf
+}
+
+// Render adds the voice's one-shot buffer into out starting at
+// startSample, per the seq.Voice contract.
+func (v *SynthVoice) Render(startSample int, out []int) {
+	bufStart := 0
+	if startSample < 0 {
+		bufStart = -startSample
+		startSample = 0
+	}
+	for i := 0; bufStart+i < len(v.buf) && startSample+i < len(out); i++ {
+		out[startSample+i] += v.buf[bufStart+i]
+	}
+}
+
+// NewVoice renders lengthMs of osc at freq through filter and env, the
+// same way the preset constructors (NewKick, NewSnare, ...) do, and wraps
+// the result as a playable SynthVoice.
+func NewVoice(osc Oscillator, freq float64, sampleRate, lengthMs int, env ADSR, filter *Biquad) *SynthVoice {
+	return &SynthVoice{buf: Render(osc, freq, sampleRate, lengthMs, env, filter)}
+}
+
+// Render renders lengthMs of osc at freq, passes it through filter (if
+// non-nil) and env, and scales the result to 16-bit PCM range, clamping
+// any sample a resonant filter pushes past ±32767. It is the building
+// block every preset constructor (NewKick, NewSnare, ...) is made from,
+// and is exported so callers can build their own one-shots from the same
+// primitives.
+func Render(osc Oscillator, freq float64, sampleRate, lengthMs int, env ADSR, filter *Biquad) []int {
+	n := sampleRate * lengthMs / 1000
+	raw := osc(sampleRate, freq, n)
+	if filter != nil {
+		raw = filter.Process(raw)
+	}
+	envelope := env.Envelope(sampleRate, n)
+
+	buf := make([]int, n)
+	for i := range buf {
+		buf[i] = clamp16(int(raw[i] * envelope[i] * 32767))
+	}
+	return buf
+}